@@ -61,19 +61,21 @@ var errorMessages = Messages{
 
 // errorBox encapsulates an error.
 type errorBox struct {
-	err  error
-	code int
-	msg  string
-	info *callInfo
+	err   error
+	code  int
+	msg   string
+	info  *callInfo
+	stack []uintptr
 }
 
 // newErrorBox creates an initialized error box.
 func newErrorBox(err error, code int, msgs Messages, args ...interface{}) *errorBox {
 	return &errorBox{
-		err:  err,
-		code: code,
-		msg:  msgs.Format(code, args...),
-		info: retrieveCallInfo(),
+		err:   err,
+		code:  code,
+		msg:   msgs.Format(code, args...),
+		info:  retrieveCallInfo(),
+		stack: callers(),
 	}
 }
 
@@ -0,0 +1,136 @@
+// Tideland Go Library - Errors
+//
+// Copyright (C) 2013-2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package errors
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+//--------------------
+// STACK TRACE
+//--------------------
+
+// maxStackDepth limits the number of program counters captured for a
+// stack trace.
+const maxStackDepth = 32
+
+// thisPackagePrefix identifies frames belonging to this package so
+// StackTrace can skip them.
+const thisPackagePrefix = "github.com/tideland/golib/errors."
+
+// callers captures the program counters of the stack that led to the
+// creation of an error box.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// StackTrace returns the stack of call frames that led to the
+// creation of err, skipping frames inside the errors package itself.
+// It returns nil if err hasn't been created by this package.
+func StackTrace(err error) []runtime.Frame {
+	eb, ok := err.(*errorBox)
+	if !ok {
+		return nil
+	}
+	frames := runtime.CallersFrames(eb.stack)
+	trace := []runtime.Frame{}
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, thisPackagePrefix) {
+			trace = append(trace, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// Format implements fmt.Formatter so that formatting err with "%+v"
+// renders it together with its full stack trace, similar to the
+// convention of github.com/pkg/errors.
+func (eb *errorBox) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprint(s, eb.Error())
+			for _, frame := range StackTrace(eb) {
+				fmt.Fprintf(s, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		fmt.Fprint(s, eb.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", eb.Error())
+	}
+}
+
+//--------------------
+// STANDARD LIBRARY INTEROPERABILITY
+//--------------------
+
+// Unwrap returns the wrapped error so that errors.Is and errors.As of
+// the standard library work transparently on an annotated error.
+func (eb *errorBox) Unwrap() error {
+	return eb.err
+}
+
+// Unwrap returns the collected errors so that errors.Is and errors.As
+// of the standard library work transparently on a collection.
+func (ec *errorCollection) Unwrap() []error {
+	return ec.errs
+}
+
+// Is reports whether err, or any error in its chain, matches target.
+// target may be an error, in which case the call is forwarded to the
+// standard library's errors.Is, or a plain numeric error code, in
+// which case it matches any *errorBox in the chain with that code.
+func Is(err error, target interface{}) bool {
+	if code, ok := target.(int); ok {
+		for _, serr := range Stack(err) {
+			if eb, ok := serr.(*errorBox); ok && eb.code == code {
+				return true
+			}
+		}
+		return false
+	}
+	if terr, ok := target.(error); ok {
+		return stderrors.Is(err, terr)
+	}
+	return false
+}
+
+// As finds the first error in err's chain that matches target, the
+// same way the standard library's errors.As does. If target is a
+// *int, it is populated with the code of the first *errorBox in err's
+// chain instead, mirroring the numeric-code matching Is supports.
+func As(err error, target interface{}) bool {
+	if code, ok := target.(*int); ok {
+		for _, serr := range Stack(err) {
+			if eb, ok := serr.(*errorBox); ok {
+				*code = eb.code
+				return true
+			}
+		}
+		return false
+	}
+	return stderrors.As(err, target)
+}
+
+// EOF
@@ -0,0 +1,89 @@
+// Tideland Go Library - Errors - Unit Tests
+//
+// Copyright (C) 2013-2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package errors_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestStackTrace checks that StackTrace returns frames for an
+// annotated error and skips frames inside the errors package itself.
+func TestStackTrace(t *testing.T) {
+	err := errors.New(errors.ErrNotYetImplemented, errors.Messages{}, "thing")
+	trace := errors.StackTrace(err)
+	if len(trace) == 0 {
+		t.Fatal("StackTrace returned no frames")
+	}
+	for _, frame := range trace {
+		if strings.HasPrefix(frame.Function, "github.com/tideland/golib/errors.") {
+			t.Errorf("StackTrace leaked an internal frame: %s", frame.Function)
+		}
+	}
+}
+
+// TestUnwrap checks that the standard library's errors.Is and
+// errors.As see through an annotated error and a collection.
+func TestUnwrap(t *testing.T) {
+	cause := stderrors.New("boom")
+	wrapped := errors.Annotate(cause, errors.ErrDeprecated, errors.Messages{}, "thing")
+	if !stderrors.Is(wrapped, cause) {
+		t.Error("stderrors.Is(wrapped, cause) = false, want true")
+	}
+	collected := errors.Collect(wrapped, stderrors.New("other"))
+	if !stderrors.Is(collected, cause) {
+		t.Error("stderrors.Is(collected, cause) = false, want true")
+	}
+}
+
+// TestIsByCode checks that Is also matches a plain numeric error
+// code, in addition to forwarding to the standard library for error
+// targets.
+func TestIsByCode(t *testing.T) {
+	cause := stderrors.New("boom")
+	wrapped := errors.Annotate(cause, errors.ErrDeprecated, errors.Messages{}, "thing")
+	if !errors.Is(wrapped, errors.ErrDeprecated) {
+		t.Error("Is(wrapped, ErrDeprecated) = false, want true")
+	}
+	if errors.Is(wrapped, errors.ErrNotYetImplemented) {
+		t.Error("Is(wrapped, ErrNotYetImplemented) = true, want false")
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Error("Is(wrapped, cause) = false, want true")
+	}
+}
+
+// TestAsByCode checks that As, given a *int target, extracts the code
+// of the first *errorBox in err's chain, the same way Is matches one.
+func TestAsByCode(t *testing.T) {
+	cause := stderrors.New("boom")
+	wrapped := errors.Annotate(cause, errors.ErrDeprecated, errors.Messages{}, "thing")
+	var code int
+	if !errors.As(wrapped, &code) {
+		t.Fatal("As(wrapped, &code) = false, want true")
+	}
+	if code != errors.ErrDeprecated {
+		t.Errorf("As(wrapped, &code): code = %d, want %d", code, errors.ErrDeprecated)
+	}
+	if errors.As(cause, &code) {
+		t.Error("As(cause, &code) = true, want false")
+	}
+}
+
+// EOF
@@ -0,0 +1,71 @@
+// Tideland Go Library - Generic JSON Processor - Classify
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"github.com/tideland/golib/version"
+)
+
+//--------------------
+// CLASSIFY
+//--------------------
+
+// ClassifyDiff classifies the API-compatibility impact of d: a
+// removed path or a changed scalar type at the same path is a
+// breaking (major) change, an added path is an additive (minor)
+// change, and a value-only change of the same JSON type is a
+// (patch) change.
+func ClassifyDiff(d Diff) version.Change {
+	change := version.ChangeNone
+	for _, path := range d.Differences() {
+		fv, sv := d.DifferenceAt(path)
+		switch {
+		case fv.IsUndefined():
+			change = maxChange(change, version.ChangeMinor)
+		case sv.IsUndefined():
+			return version.ChangeMajor
+		case jsonType(fv) != jsonType(sv):
+			return version.ChangeMajor
+		default:
+			change = maxChange(change, version.ChangePatch)
+		}
+	}
+	return change
+}
+
+// maxChange returns the more significant of a and b.
+func maxChange(a, b version.Change) version.Change {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// jsonType returns a short tag for the JSON type of a leaf value:
+// "null", "bool", "number", or "string", and "other" for an (empty)
+// object or array.
+func jsonType(v Value) string {
+	switch v.Raw().(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	default:
+		return "other"
+	}
+}
+
+// EOF
@@ -0,0 +1,82 @@
+// Tideland Go Library - Generic JSON Processor - Unit Tests
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"github.com/tideland/golib/gjp"
+	"github.com/tideland/golib/version"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestClassifyDiff checks that ClassifyDiff derives the expected
+// API-compatibility change from a pair of documents.
+func TestClassifyDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		second string
+		want   version.Change
+	}{
+		{
+			name:   "no change",
+			first:  `{"a":1}`,
+			second: `{"a":1}`,
+			want:   version.ChangeNone,
+		},
+		{
+			name:   "value-only change",
+			first:  `{"a":1}`,
+			second: `{"a":2}`,
+			want:   version.ChangePatch,
+		},
+		{
+			name:   "added path",
+			first:  `{"a":1}`,
+			second: `{"a":1,"b":2}`,
+			want:   version.ChangeMinor,
+		},
+		{
+			name:   "removed path",
+			first:  `{"a":1,"b":2}`,
+			second: `{"a":1}`,
+			want:   version.ChangeMajor,
+		},
+		{
+			name:   "type change",
+			first:  `{"a":1}`,
+			second: `{"a":"one"}`,
+			want:   version.ChangeMajor,
+		},
+		{
+			name:   "add and patch combine to minor",
+			first:  `{"a":1}`,
+			second: `{"a":2,"b":3}`,
+			want:   version.ChangeMinor,
+		},
+	}
+	for _, test := range tests {
+		d, err := gjp.Compare([]byte(test.first), []byte(test.second), "/")
+		if err != nil {
+			t.Fatalf("%s: Compare returned error: %v", test.name, err)
+		}
+		if got := gjp.ClassifyDiff(d); got != test.want {
+			t.Errorf("%s: ClassifyDiff(d) = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// EOF
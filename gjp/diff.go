@@ -32,6 +32,10 @@ type Diff interface {
 	// DifferenceAt returns the differences at the given path by
 	// returning the first and the second value.
 	DifferenceAt(path string) (Value, Value)
+
+	// Patch renders the differences as an RFC 6902 JSON Patch document
+	// that turns the first document into the second one.
+	Patch() ([]byte, error)
 }
 
 // diff implements Diff.
@@ -0,0 +1,321 @@
+// Tideland Go Library - Generic JSON Processor - Document
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// DOCUMENT
+//--------------------
+
+// ValueProcessor is called once per leaf value found while processing
+// a document; path is joined using the document's separator.
+type ValueProcessor func(path string, value Value) error
+
+// Document provides generic, path-based access to a parsed JSON
+// document.
+type Document interface {
+	// Length returns the number of elements at path if it addresses
+	// an object or array, or -1 otherwise.
+	Length(path string) int
+
+	// ValueAt returns the value at path, or an undefined Value if
+	// path doesn't address an existing value.
+	ValueAt(path string) Value
+
+	// Process visits every leaf value of the document, in a
+	// deterministic (object keys sorted) order.
+	Process(processor ValueProcessor) error
+
+	// SetValueAt sets the value at path, creating the key if its
+	// parent object doesn't have it yet.
+	SetValueAt(path string, value interface{}) error
+
+	// Clear removes the value at path.
+	Clear(path string) error
+
+	// String returns the document marshaled back to JSON.
+	String() string
+}
+
+// document implements Document based on the generic tree produced by
+// encoding/json: map[string]interface{}, []interface{}, and scalars.
+type document struct {
+	root      interface{}
+	separator string
+}
+
+// Parse parses data as JSON and returns it as a Document whose paths
+// are joined with separator.
+func Parse(data []byte, separator string) (Document, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, errors.Annotate(err, ErrInvalidDocument, errorMessages, err)
+	}
+	return &document{root: root, separator: separator}, nil
+}
+
+// segments splits path into its separator-delimited parts.
+func (d *document) segments(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, d.separator)
+}
+
+func (d *document) Length(path string) int {
+	raw, ok := lookupAt(d.root, d.segments(path))
+	if !ok {
+		return -1
+	}
+	switch node := raw.(type) {
+	case map[string]interface{}:
+		return len(node)
+	case []interface{}:
+		return len(node)
+	default:
+		return -1
+	}
+}
+
+func (d *document) ValueAt(path string) Value {
+	raw, ok := lookupAt(d.root, d.segments(path))
+	if !ok {
+		return undefinedValue()
+	}
+	return newValue(raw)
+}
+
+func (d *document) Process(processor ValueProcessor) error {
+	return processNode(d.root, nil, d.separator, processor)
+}
+
+func (d *document) SetValueAt(path string, v interface{}) error {
+	return d.setValueAt(path, v, false)
+}
+
+// setValueAt is SetValueAt's unexported counterpart used by ApplyPatch:
+// with vivify, a missing intermediate object or array on the path is
+// created (as an "add" may introduce a whole new subtree) instead of
+// failing with ErrInvalidPatch.
+func (d *document) setValueAt(path string, v interface{}, vivify bool) error {
+	segments := d.segments(path)
+	if len(segments) == 0 {
+		d.root = v
+		return nil
+	}
+	updated, err := setAt(d.root, segments, v, vivify)
+	if err != nil {
+		return err
+	}
+	d.root = updated
+	return nil
+}
+
+func (d *document) Clear(path string) error {
+	segments := d.segments(path)
+	if len(segments) == 0 {
+		d.root = nil
+		return nil
+	}
+	updated, err := clearAt(d.root, segments)
+	if err != nil {
+		return err
+	}
+	d.root = updated
+	return nil
+}
+
+func (d *document) String() string {
+	raw, err := json.Marshal(d.root)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+//--------------------
+// TREE WALKING
+//--------------------
+
+// lookupAt descends node following segments and returns the value
+// found there, or false if segments addresses nothing.
+func lookupAt(node interface{}, segments []string) (interface{}, bool) {
+	cur := node
+	for _, seg := range segments {
+		switch n := cur.(type) {
+		case map[string]interface{}:
+			v, ok := n[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return nil, false
+			}
+			cur = n[idx]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// processNode visits every leaf of node, joining prefix with separator
+// to build each leaf's path. Object keys are visited in sorted order
+// so that repeated runs process the same document identically.
+func processNode(node interface{}, prefix []string, separator string, processor ValueProcessor) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if len(n) == 0 {
+			return processor(strings.Join(prefix, separator), newValue(n))
+		}
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			next := append(append([]string{}, prefix...), k)
+			if err := processNode(n[k], next, separator, processor); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		if len(n) == 0 {
+			return processor(strings.Join(prefix, separator), newValue(n))
+		}
+		for i, v := range n {
+			next := append(append([]string{}, prefix...), strconv.Itoa(i))
+			if err := processNode(v, next, separator, processor); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return processor(strings.Join(prefix, separator), newValue(n))
+	}
+}
+
+// setAt returns node with the value at segments replaced by v, adding
+// a new object key or array element if the last segment doesn't exist
+// yet. With vivify, a missing intermediate object or array is created
+// instead of failing, the next segment deciding whether it becomes a
+// map or a slice; without it, every intermediate segment must already
+// exist.
+func setAt(node interface{}, segments []string, v interface{}, vivify bool) (interface{}, error) {
+	if len(segments) == 0 {
+		return v, nil
+	}
+	seg := segments[0]
+	rest := segments[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, exists := n[seg]
+		if !exists && len(rest) > 0 && !vivify {
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "missing parent path at "+seg)
+		}
+		updated, err := setAt(child, rest, v, vivify)
+		if err != nil {
+			return nil, err
+		}
+		n[seg] = updated
+		return n, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx > len(n) {
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "index out of bounds at "+seg)
+		}
+		if idx == len(n) {
+			if len(rest) > 0 && !vivify {
+				return nil, errors.New(ErrInvalidPatch, errorMessages, "missing parent path at "+seg)
+			}
+			elem, err := setAt(nil, rest, v, vivify)
+			if err != nil {
+				return nil, err
+			}
+			return append(n, elem), nil
+		}
+		updated, err := setAt(n[idx], rest, v, vivify)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	default:
+		if n != nil || !vivify {
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "missing parent path at "+seg)
+		}
+		return setAt(newContainer(seg), segments, v, vivify)
+	}
+}
+
+// newContainer returns an empty object or array to vivify a missing
+// intermediate path segment, choosing an array if seg addresses one
+// of its indices, an object otherwise.
+func newContainer(seg string) interface{} {
+	if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 {
+		return []interface{}{}
+	}
+	return map[string]interface{}{}
+}
+
+// clearAt returns node with the value at segments removed.
+func clearAt(node interface{}, segments []string) (interface{}, error) {
+	seg := segments[0]
+	rest := segments[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, exists := n[seg]
+		if !exists {
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "missing parent path at "+seg)
+		}
+		if len(rest) == 0 {
+			delete(n, seg)
+			return n, nil
+		}
+		updated, err := clearAt(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		n[seg] = updated
+		return n, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx >= len(n) {
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "index out of bounds at "+seg)
+		}
+		if len(rest) == 0 {
+			return append(n[:idx], n[idx+1:]...), nil
+		}
+		updated, err := clearAt(n[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	default:
+		return nil, errors.New(ErrInvalidPatch, errorMessages, "missing parent path at "+seg)
+	}
+}
+
+// EOF
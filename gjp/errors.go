@@ -0,0 +1,33 @@
+// Tideland Go Library - Generic JSON Processor
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// ERROR CODES
+//--------------------
+
+// Error codes of the gjp package.
+const (
+	ErrInvalidDocument = iota + 1
+	ErrInvalidPatch
+)
+
+var errorMessages = errors.Messages{
+	ErrInvalidDocument: "invalid document: %v",
+	ErrInvalidPatch:    "invalid JSON patch: %v",
+}
+
+// EOF
@@ -0,0 +1,169 @@
+// Tideland Go Library - Generic JSON Processor - Patch
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// PATCH
+//--------------------
+
+// patchOperation is one RFC 6902 JSON Patch operation. Value is a
+// pointer so that an explicit JSON null can still be rendered for
+// "add"/"replace" (a nil interface{} would otherwise be dropped by
+// omitempty), while "remove" leaves it nil to omit the member.
+type patchOperation struct {
+	Op    string       `json:"op"`
+	Path  string       `json:"path"`
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// valuePtr returns v boxed as the pointer patchOperation.Value needs.
+func valuePtr(v interface{}) *interface{} {
+	return &v
+}
+
+// Patch renders the differences of d as an RFC 6902 JSON Patch
+// document that turns the first document into the second one: a path
+// present only in the second document is an "add", a path present
+// only in the first is a "remove", and a path present in both with
+// different values is a "replace".
+func (d *diff) Patch() ([]byte, error) {
+	separator := d.separator()
+	ops := make([]patchOperation, 0, len(d.paths))
+	for _, path := range d.paths {
+		fv, sv := d.DifferenceAt(path)
+		pointer := toJSONPointer(path, separator)
+		switch {
+		case fv.IsUndefined():
+			ops = append(ops, patchOperation{Op: "add", Path: pointer, Value: valuePtr(sv.Raw())})
+		case sv.IsUndefined():
+			ops = append(ops, patchOperation{Op: "remove", Path: pointer})
+		default:
+			ops = append(ops, patchOperation{Op: "replace", Path: pointer, Value: valuePtr(sv.Raw())})
+		}
+	}
+	// Each run of consecutive removals is reversed, so that removing an
+	// array element doesn't shift the index of the next one to remove.
+	for i := 0; i < len(ops); i++ {
+		j := i
+		for j < len(ops) && ops[j].Op == "remove" {
+			j++
+		}
+		for l, r := i, j-1; l < r; l, r = l+1, r-1 {
+			ops[l], ops[r] = ops[r], ops[l]
+		}
+		i = j
+	}
+	return json.Marshal(ops)
+}
+
+// PatchBetween directly compares first and second and renders their
+// differences as an RFC 6902 JSON Patch document.
+func PatchBetween(first, second Document) ([]byte, error) {
+	d, err := CompareDocuments(first, second, "/")
+	if err != nil {
+		return nil, err
+	}
+	return d.Patch()
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch document to doc and
+// returns the resulting document, so that applying PatchBetween(first,
+// second) to first round-trips to second.
+func ApplyPatch(doc Document, patch []byte) (Document, error) {
+	d, ok := doc.(*document)
+	if !ok {
+		return nil, errors.New(ErrInvalidDocument, errorMessages, "doc")
+	}
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, errors.Annotate(err, ErrInvalidPatch, errorMessages, err)
+	}
+	for _, op := range ops {
+		path, err := fromJSONPointer(op.Path, d.separator)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add", "replace":
+			var v interface{}
+			if op.Value != nil {
+				v = *op.Value
+			}
+			// "add" may introduce a whole new subtree, so its missing
+			// intermediate containers are vivified; "replace" targets
+			// an existing path and must not paper over a missing one.
+			if op.Op == "replace" && d.ValueAt(path).IsUndefined() {
+				return nil, errors.New(ErrInvalidPatch, errorMessages, "missing path "+op.Path)
+			}
+			if err := d.setValueAt(path, v, op.Op == "add"); err != nil {
+				return nil, errors.Annotate(err, ErrInvalidPatch, errorMessages, err)
+			}
+		case "remove":
+			if err := d.Clear(path); err != nil {
+				return nil, errors.Annotate(err, ErrInvalidPatch, errorMessages, err)
+			}
+		default:
+			return nil, errors.New(ErrInvalidPatch, errorMessages, "operation "+op.Op)
+		}
+	}
+	return d, nil
+}
+
+// separator returns the path separator of the diffed documents.
+func (d *diff) separator() string {
+	if fd, ok := d.first.(*document); ok {
+		return fd.separator
+	}
+	return "/"
+}
+
+// toJSONPointer converts a gjp path using separator into an RFC 6901
+// JSON Pointer, escaping "~" and "/" in each segment.
+func toJSONPointer(path, separator string) string {
+	if path == "" {
+		return ""
+	}
+	segments := strings.Split(path, separator)
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(segment, "~", "~0")
+		segment = strings.ReplaceAll(segment, "/", "~1")
+		segments[i] = segment
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// fromJSONPointer converts an RFC 6901 JSON Pointer back into a gjp
+// path using separator.
+func fromJSONPointer(pointer, separator string) (string, error) {
+	if pointer == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return "", errors.New(ErrInvalidPatch, errorMessages, "pointer "+pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segment = strings.ReplaceAll(segment, "~0", "~")
+		segments[i] = segment
+	}
+	return strings.Join(segments, separator), nil
+}
+
+// EOF
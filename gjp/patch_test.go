@@ -0,0 +1,158 @@
+// Tideland Go Library - Generic JSON Processor - Unit Tests
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tideland/golib/gjp"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestPatchRoundTrip checks that applying PatchBetween(first, second)
+// to first reproduces second, for additions, removals, replacements,
+// and an explicit JSON null value.
+func TestPatchRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		second string
+	}{
+		{
+			name:   "replace and add",
+			first:  `{"a":1,"b":{"c":2}}`,
+			second: `{"a":1,"b":{"c":3,"d":4}}`,
+		},
+		{
+			name:   "remove",
+			first:  `{"a":1,"b":2}`,
+			second: `{"a":1}`,
+		},
+		{
+			name:   "add explicit null",
+			first:  `{"a":1}`,
+			second: `{"a":1,"b":null}`,
+		},
+		{
+			name:   "replace with null",
+			first:  `{"a":1}`,
+			second: `{"a":null}`,
+		},
+		{
+			name:   "array element replace",
+			first:  `{"items":[1,2,3]}`,
+			second: `{"items":[1,5,3]}`,
+		},
+		{
+			name:   "add nested object",
+			first:  `{"a":1}`,
+			second: `{"a":1,"b":{"c":2}}`,
+		},
+		{
+			name:   "add nested array",
+			first:  `{"a":1}`,
+			second: `{"a":1,"b":[1,2]}`,
+		},
+		{
+			name:   "add deeply nested object",
+			first:  `{"a":1}`,
+			second: `{"a":1,"b":{"c":{"d":2}}}`,
+		},
+	}
+	for _, test := range tests {
+		first, err := gjp.Parse([]byte(test.first), "/")
+		if err != nil {
+			t.Fatalf("%s: Parse(first) returned error: %v", test.name, err)
+		}
+		second, err := gjp.Parse([]byte(test.second), "/")
+		if err != nil {
+			t.Fatalf("%s: Parse(second) returned error: %v", test.name, err)
+		}
+		patch, err := gjp.PatchBetween(first, second)
+		if err != nil {
+			t.Fatalf("%s: PatchBetween returned error: %v", test.name, err)
+		}
+		applied, err := gjp.ApplyPatch(first, patch)
+		if err != nil {
+			t.Fatalf("%s: ApplyPatch returned error: %v", test.name, err)
+		}
+		if applied.String() != second.String() {
+			t.Errorf("%s: ApplyPatch(first, patch) = %s, want %s", test.name, applied.String(), second.String())
+		}
+	}
+}
+
+// TestPatchEscapesPointers checks that "~" in a path segment is
+// rendered as the RFC 6901 "~0" escape, and that an unchanged path,
+// here a key holding a "/", doesn't show up in the patch at all. The
+// documents use "." as separator so that the "/" stays inside its key.
+func TestPatchEscapesPointers(t *testing.T) {
+	d, err := gjp.Compare(
+		[]byte(`{"a/b":1,"c~d":2,"e":3}`),
+		[]byte(`{"a/b":1,"c~d":5,"f":6}`),
+		".",
+	)
+	if err != nil {
+		t.Fatalf("Compare returned error: %v", err)
+	}
+	patch, err := d.Patch()
+	if err != nil {
+		t.Fatalf("Patch returned error: %v", err)
+	}
+	if !strings.Contains(string(patch), `"path":"/c~0d"`) {
+		t.Errorf("Patch() = %s, want an escaped pointer for \"c~d\"", patch)
+	}
+	if strings.Contains(string(patch), `a~1b`) {
+		t.Errorf("Patch() = %s, unchanged path \"a/b\" must not appear", patch)
+	}
+}
+
+// TestApplyPatchInvalid checks that ApplyPatch rejects a patch
+// referencing a missing parent path or an out-of-bounds array index.
+func TestApplyPatchInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+	}{
+		{
+			name:  "replace with missing parent",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"/b/c","value":1}]`,
+		},
+		{
+			name:  "index out of bounds",
+			doc:   `{"items":[1,2]}`,
+			patch: `[{"op":"replace","path":"/items/5","value":1}]`,
+		},
+		{
+			name:  "unknown operation",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"test","path":"/a","value":1}]`,
+		},
+	}
+	for _, test := range tests {
+		doc, err := gjp.Parse([]byte(test.doc), "/")
+		if err != nil {
+			t.Fatalf("%s: Parse returned error: %v", test.name, err)
+		}
+		if _, err := gjp.ApplyPatch(doc, []byte(test.patch)); err == nil {
+			t.Errorf("%s: ApplyPatch returned no error, want ErrInvalidPatch", test.name)
+		}
+	}
+}
+
+// EOF
@@ -0,0 +1,80 @@
+// Tideland Go Library - Generic JSON Processor - Value
+//
+// Copyright (C) 2017 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package gjp
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/json"
+)
+
+//--------------------
+// VALUE
+//--------------------
+
+// Value represents a single value of a document, as found at a path
+// passed to Document.ValueAt() or visited during Document.Process().
+type Value interface {
+	// IsUndefined returns true if the value doesn't exist in the
+	// document, e.g. because its path doesn't address anything.
+	IsUndefined() bool
+
+	// Raw returns the value's underlying Go representation, as
+	// produced by encoding/json: nil, bool, float64, string,
+	// []interface{}, or map[string]interface{}.
+	Raw() interface{}
+
+	// Equals returns true if v and to represent the same JSON value.
+	Equals(to Value) bool
+}
+
+// value implements Value.
+type value struct {
+	raw       interface{}
+	undefined bool
+}
+
+// newValue returns raw as a defined Value.
+func newValue(raw interface{}) Value {
+	return &value{raw: raw}
+}
+
+// undefinedValue returns the Value of a path that doesn't exist.
+func undefinedValue() Value {
+	return &value{undefined: true}
+}
+
+// IsUndefined implements Value.
+func (v *value) IsUndefined() bool {
+	return v.undefined
+}
+
+// Raw implements Value.
+func (v *value) Raw() interface{} {
+	return v.raw
+}
+
+// Equals implements Value.
+func (v *value) Equals(to Value) bool {
+	if v.IsUndefined() || to.IsUndefined() {
+		return v.IsUndefined() == to.IsUndefined()
+	}
+	vraw, err := json.Marshal(v.raw)
+	if err != nil {
+		return false
+	}
+	toraw, err := json.Marshal(to.Raw())
+	if err != nil {
+		return false
+	}
+	return string(vraw) == string(toraw)
+}
+
+// EOF
@@ -0,0 +1,35 @@
+// Tideland Go Library - Version
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// ERROR CODES
+//--------------------
+
+// Error codes of the version package.
+const (
+	ErrIllegalVersionFormat = iota + 1
+	ErrIllegalRangeFormat
+	ErrCannotSuggest
+)
+
+var errorMessages = errors.Messages{
+	ErrIllegalVersionFormat: "illegal version format: %q",
+	ErrIllegalRangeFormat:   "illegal version range format: %q",
+	ErrCannotSuggest:        "cannot suggest a version following %q",
+}
+
+// EOF
@@ -0,0 +1,131 @@
+// Tideland Go Library - Version
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// PSEUDO VERSION
+//--------------------
+
+// pseudoTimestampFormat is the layout of the timestamp embedded in a
+// pseudo-version, always expressed in UTC.
+const pseudoTimestampFormat = "20060102150405"
+
+// pseudoRE matches the three cmd/go pseudo-version shapes:
+// "vX.Y.Z-yyyymmddhhmmss-abcdefabcdef" when no base tag exists at all,
+// "vX.Y.Z-0.yyyymmddhhmmss-abcdefabcdef" when the base is a release,
+// and "vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef" when the base is a
+// pre-release. The label before the ".0." marker, and the marker
+// itself, are therefore both optional.
+var pseudoRE = regexp.MustCompile(
+	`^v?(\d+)\.(\d+)\.(\d+)-(?:(?:([0-9A-Za-z-]+)\.)?(0)\.)?(\d{14})-([0-9a-f]{12})$`,
+)
+
+// ParsePseudo recognizes Go's pseudo-version form
+// "vX.Y.Z-0.yyyymmddhhmmss-abcdefabcdef" (and its "-pre.0.<date>-<rev>"
+// pre-release-base and bare "-<date>-<rev>" no-base-tag variants) and
+// returns it as a Version whose IsPseudo, Timestamp and Revision
+// accessors expose its embedded data.
+func ParsePseudo(vsnstr string) (Version, error) {
+	m := pseudoRE.FindStringSubmatch(vsnstr)
+	if m == nil {
+		return nil, errors.New(ErrIllegalVersionFormat, errorMessages, vsnstr)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	basePreRelease := m[4]
+	// bare is the no-base-tag shape, which carries neither a label
+	// nor the "0." marker in front of the timestamp.
+	bare := m[4] == "" && m[5] == ""
+	ts, err := time.ParseInLocation(pseudoTimestampFormat, m[6], time.UTC)
+	if err != nil {
+		return nil, errors.Annotate(err, ErrIllegalVersionFormat, errorMessages, vsnstr)
+	}
+	v := New(major, minor, patch, preReleaseArgs(basePreRelease)...).(*vsn)
+	v.pseudo = true
+	v.pseudoBare = bare
+	v.timestamp = ts
+	v.revision = m[7]
+	return v, nil
+}
+
+// PseudoVersion builds a canonical Go module pseudo-version derived
+// from base, a commit timestamp, and a revision, following the
+// cmd/go rules: the revision is truncated to 12 characters, the
+// timestamp is rendered in UTC, the "-0." marker is used in place of
+// a base pre-release when base is nil or has none, and the patch
+// number is bumped when base is a release, so the pseudo-version
+// sorts strictly between that release and the next one.
+func PseudoVersion(base Version, t time.Time, rev string) Version {
+	major, minor, patch := 0, 0, 0
+	label := ""
+	if base != nil {
+		major, minor, patch = base.Major(), base.Minor(), base.Patch()
+		label = base.PreRelease()
+		if label == "" {
+			patch++
+		}
+	}
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	ts := t.UTC().Format(pseudoTimestampFormat)
+	var vsnstr string
+	if label != "" {
+		vsnstr = fmt.Sprintf("%d.%d.%d-%s.0.%s-%s", major, minor, patch, label, ts, rev)
+	} else {
+		vsnstr = fmt.Sprintf("%d.%d.%d-0.%s-%s", major, minor, patch, ts, rev)
+	}
+	v, err := ParsePseudo(vsnstr)
+	if err != nil {
+		// base is under our own control above, so this can only
+		// happen if rev isn't a valid revision: shorter than 12
+		// characters or not lowercase hexadecimal.
+		return New(major, minor, patch)
+	}
+	return v
+}
+
+// lessPseudo compares two versions sharing the same major, minor, and
+// patch number of which at least one is a pseudo-version. Two
+// pseudo-versions derived from the same base pre-release are ordered
+// by their embedded timestamp; a release- or bare-based pseudo (no
+// label of its own) always sorts below a plain release sharing its
+// triple, since PseudoVersion already bumped the patch so that triple
+// is the next release, not the one the pseudo was built from.
+func lessPseudo(v *vsn, cv Version) bool {
+	vpr := v.PreRelease()
+	cvpr := cv.PreRelease()
+	switch {
+	case v.pseudo && cv.IsPseudo() && vpr == cvpr:
+		return v.timestamp.Before(cv.Timestamp())
+	case v.pseudo && vpr == "" && !cv.IsPseudo() && cvpr == "":
+		return true
+	}
+	cvprs := []string{}
+	if len(cvpr) > 0 {
+		cvprs = strings.Split(cvpr, ".")
+	}
+	return less(v.preRelease, cvprs)
+}
+
+// EOF
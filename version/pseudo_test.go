@@ -0,0 +1,137 @@
+// Tideland Go Library - Version - Unit Tests
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tideland/golib/version"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestParsePseudoForms checks that all three cmd/go pseudo-version
+// shapes are recognized and their embedded data is exposed correctly.
+func TestParsePseudoForms(t *testing.T) {
+	ts := time.Date(2019, time.November, 9, 2, 19, 31, 0, time.UTC)
+	tests := []struct {
+		vsnstr string
+		major  int
+		minor  int
+		patch  int
+		pre    string
+	}{
+		{"v0.0.0-20191109021931-daa7c04131f5", 0, 0, 0, ""},
+		{"v1.2.4-0.20191109021931-daa7c04131f5", 1, 2, 4, ""},
+		{"v1.2.4-pre.0.20191109021931-daa7c04131f5", 1, 2, 4, "pre"},
+	}
+	for _, test := range tests {
+		v, err := version.ParsePseudo(test.vsnstr)
+		if err != nil {
+			t.Errorf("ParsePseudo(%q) returned error: %v", test.vsnstr, err)
+			continue
+		}
+		if !v.IsPseudo() {
+			t.Errorf("ParsePseudo(%q).IsPseudo() = false, want true", test.vsnstr)
+		}
+		if v.Major() != test.major || v.Minor() != test.minor || v.Patch() != test.patch {
+			t.Errorf("ParsePseudo(%q) = %d.%d.%d, want %d.%d.%d",
+				test.vsnstr, v.Major(), v.Minor(), v.Patch(), test.major, test.minor, test.patch)
+		}
+		if v.PreRelease() != test.pre {
+			t.Errorf("ParsePseudo(%q).PreRelease() = %q, want %q", test.vsnstr, v.PreRelease(), test.pre)
+		}
+		if v.Revision() != "daa7c04131f5" {
+			t.Errorf("ParsePseudo(%q).Revision() = %q, want %q", test.vsnstr, v.Revision(), "daa7c04131f5")
+		}
+		if !v.Timestamp().Equal(ts) {
+			t.Errorf("ParsePseudo(%q).Timestamp() = %v, want %v", test.vsnstr, v.Timestamp(), ts)
+		}
+		if got := v.String(); got != test.vsnstr[1:] {
+			t.Errorf("ParsePseudo(%q).String() = %q, want %q", test.vsnstr, got, test.vsnstr[1:])
+		}
+	}
+}
+
+// TestParsePseudoInvalid checks that malformed pseudo-versions are
+// rejected rather than silently matched.
+func TestParsePseudoInvalid(t *testing.T) {
+	tests := []string{
+		"v1.2.4-20191109021931-daa7c04131",     // revision too short
+		"v1.2.4-0.2019110902-daa7c04131f5",     // timestamp too short
+		"v1.2.4-0.20191109021931-DAA7C04131F5", // uppercase revision
+		"v1.2.4",
+	}
+	for _, vsnstr := range tests {
+		if _, err := version.ParsePseudo(vsnstr); err == nil {
+			t.Errorf("ParsePseudo(%q) returned no error, want ErrIllegalVersionFormat", vsnstr)
+		}
+	}
+}
+
+// TestPseudoVersionRoundTrip checks that PseudoVersion produces a
+// string Parse can read back into an equivalent pseudo-version, for
+// both a release base and a pre-release base, as well as no base at
+// all.
+func TestPseudoVersionRoundTrip(t *testing.T) {
+	ts := time.Date(2019, time.November, 9, 2, 19, 31, 0, time.UTC)
+	rev := "daa7c04131f5extra"
+	tests := []version.Version{
+		version.PseudoVersion(nil, ts, rev),
+		version.PseudoVersion(version.New(1, 2, 3), ts, rev),
+		version.PseudoVersion(version.New(1, 2, 3, "beta"), ts, rev),
+	}
+	for _, v := range tests {
+		if !v.IsPseudo() {
+			t.Errorf("PseudoVersion(...).IsPseudo() = false, want true")
+		}
+		if v.Revision() != "daa7c04131f5" {
+			t.Errorf("PseudoVersion(...).Revision() = %q, want %q", v.Revision(), "daa7c04131f5")
+		}
+		parsed, err := version.Parse(v.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", v.String(), err)
+		}
+		if parsed.String() != v.String() {
+			t.Errorf("Parse(%q).String() = %q, want %q", v.String(), parsed.String(), v.String())
+		}
+	}
+}
+
+// TestPseudoVersionLess checks that a pseudo-version's precedence
+// matches cmd/go: built on a release, it sorts above that release but
+// below the next one; two pseudo-versions sharing a base are ordered
+// by their embedded timestamp.
+func TestPseudoVersionLess(t *testing.T) {
+	base := version.New(1, 2, 3)
+	next := version.New(1, 2, 4)
+	early := version.PseudoVersion(base, time.Date(2019, time.November, 9, 0, 0, 0, 0, time.UTC), "daa7c04131f5")
+	late := version.PseudoVersion(base, time.Date(2019, time.November, 10, 0, 0, 0, 0, time.UTC), "daa7c04131f5")
+
+	if early.Less(base) {
+		t.Errorf("pseudo-version built on %s must not sort below it", base)
+	}
+	if !early.Less(next) {
+		t.Errorf("pseudo-version built on %s must sort below the next release %s", base, next)
+	}
+	if !early.Less(late) {
+		t.Errorf("earlier pseudo-version must sort below a later one with the same base")
+	}
+	if late.Less(early) {
+		t.Errorf("later pseudo-version must not sort below an earlier one with the same base")
+	}
+}
+
+// EOF
@@ -0,0 +1,343 @@
+// Tideland Go Library - Version
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// RANGE
+//--------------------
+
+// Range defines a semantic version constraint built out of one or
+// more comparator sets. A version is contained in a range if it
+// matches at least one of those sets.
+type Range interface {
+	// String returns the original range expression.
+	String() string
+
+	// Contains returns true if v satisfies the range.
+	Contains(v Version) bool
+}
+
+// comparatorOp identifies a single relational operator.
+type comparatorOp int
+
+// The supported comparator operators.
+const (
+	opEQ comparatorOp = iota
+	opLT
+	opLE
+	opGT
+	opGE
+)
+
+// comparator is one primitive "<op> major.minor.patch[-prerelease]"
+// test.
+type comparator struct {
+	op comparatorOp
+	v  Version
+}
+
+// matches checks v against the comparator.
+func (c comparator) matches(v Version) bool {
+	switch c.op {
+	case opEQ:
+		return !v.Less(c.v) && !c.v.Less(v)
+	case opLT:
+		return v.Less(c.v)
+	case opLE:
+		return v.Less(c.v) || !c.v.Less(v)
+	case opGT:
+		return c.v.Less(v)
+	case opGE:
+		return !v.Less(c.v)
+	}
+	return false
+}
+
+// rng implements Range as an OR of AND-groups of comparators.
+type rng struct {
+	raw    string
+	groups [][]comparator
+}
+
+// ParseRange parses a range expression like ">=1.2.3 <2.0.0", "~1.2",
+// "^1.2.3", or "1.2.x". AND-groups are comparators joined by "," or
+// whitespace, OR-groups are joined by "||".
+func ParseRange(rngstr string) (Range, error) {
+	trimmed := strings.TrimSpace(rngstr)
+	if trimmed == "" {
+		return nil, errors.New(ErrIllegalRangeFormat, errorMessages, rngstr)
+	}
+	orParts := strings.Split(trimmed, "||")
+	groups := make([][]comparator, len(orParts))
+	for i, orPart := range orParts {
+		if strings.TrimSpace(orPart) == "" {
+			return nil, errors.New(ErrIllegalRangeFormat, errorMessages, rngstr)
+		}
+		group, err := parseAndGroup(orPart)
+		if err != nil {
+			return nil, err
+		}
+		groups[i] = group
+	}
+	return &rng{raw: trimmed, groups: groups}, nil
+}
+
+// MustParseRange is like ParseRange but panics if rngstr cannot be
+// parsed. It is intended for tests and package initialization.
+func MustParseRange(rngstr string) Range {
+	r, err := ParseRange(rngstr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the original range expression.
+func (r *rng) String() string {
+	return r.raw
+}
+
+// Contains returns true if v satisfies the range.
+func (r *rng) Contains(v Version) bool {
+	if v.PreRelease() != "" && !r.allowsPreRelease(v) {
+		return false
+	}
+	for _, group := range r.groups {
+		if matchesGroup(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsPreRelease returns true if the range explicitly names a
+// comparator version with the same major.minor.patch tuple as v and a
+// pre-release of its own; only then may a pre-release version of v
+// satisfy the range.
+func (r *rng) allowsPreRelease(v Version) bool {
+	for _, group := range r.groups {
+		for _, c := range group {
+			if c.v.PreRelease() == "" {
+				continue
+			}
+			if c.v.Major() == v.Major() && c.v.Minor() == v.Minor() && c.v.Patch() == v.Patch() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesGroup returns true if v matches every comparator of group.
+func matchesGroup(group []comparator, v Version) bool {
+	for _, c := range group {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+//--------------------
+// PARSING
+//--------------------
+
+// parseAndGroup parses one AND-group, whose comparators may be
+// separated by commas or whitespace.
+func parseAndGroup(part string) ([]comparator, error) {
+	tokens := strings.FieldsFunc(part, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	group := []comparator{}
+	for _, token := range tokens {
+		cs, err := parseComparatorToken(token)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, cs...)
+	}
+	return group, nil
+}
+
+// parseComparatorToken parses a single token of a range expression
+// into the comparators it expands to.
+func parseComparatorToken(token string) ([]comparator, error) {
+	switch {
+	case strings.HasPrefix(token, ">="):
+		return parseOpToken(token[2:], opGE)
+	case strings.HasPrefix(token, "<="):
+		return parseOpToken(token[2:], opLE)
+	case strings.HasPrefix(token, ">"):
+		return parseOpToken(token[1:], opGT)
+	case strings.HasPrefix(token, "<"):
+		return parseOpToken(token[1:], opLT)
+	case strings.HasPrefix(token, "="):
+		return parseOpToken(token[1:], opEQ)
+	case strings.HasPrefix(token, "~"):
+		return parseTildeToken(token[1:])
+	case strings.HasPrefix(token, "^"):
+		return parseCaretToken(token[1:])
+	default:
+		return parseBareToken(token)
+	}
+}
+
+// parseOpToken parses "major[.minor[.patch]][-prerelease]" following
+// an explicit relational operator.
+func parseOpToken(rest string, op comparatorOp) ([]comparator, error) {
+	nums, given, pre, err := parsePartialVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	if !given[0] {
+		return nil, errors.New(ErrIllegalRangeFormat, errorMessages, rest)
+	}
+	return []comparator{{op: op, v: New(nums[0], nums[1], nums[2], preReleaseArgs(pre)...)}}, nil
+}
+
+// parseTildeToken expands a "~major.minor.patch" token: patch-level
+// changes are allowed if a minor is specified, minor-level changes
+// otherwise.
+func parseTildeToken(rest string) ([]comparator, error) {
+	nums, given, pre, err := parsePartialVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	if !given[0] {
+		return nil, errors.New(ErrIllegalRangeFormat, errorMessages, rest)
+	}
+	lower := New(nums[0], nums[1], nums[2], preReleaseArgs(pre)...)
+	var upper Version
+	if given[1] {
+		upper = New(nums[0], nums[1]+1, 0)
+	} else {
+		upper = New(nums[0]+1, 0, 0)
+	}
+	return []comparator{{opGE, lower}, {opLT, upper}}, nil
+}
+
+// parseCaretToken expands a "^major.minor.patch" token: changes that
+// do not modify the left-most non-zero element are allowed.
+func parseCaretToken(rest string) ([]comparator, error) {
+	nums, given, pre, err := parsePartialVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	if !given[0] {
+		return nil, errors.New(ErrIllegalRangeFormat, errorMessages, rest)
+	}
+	lower := New(nums[0], nums[1], nums[2], preReleaseArgs(pre)...)
+	un := caretUpperBound(nums[0], nums[1], nums[2], given[1], given[2])
+	upper := New(un[0], un[1], un[2])
+	return []comparator{{opGE, lower}, {opLT, upper}}, nil
+}
+
+// caretUpperBound computes the exclusive upper bound of a caret range.
+func caretUpperBound(major, minor, patch int, minorGiven, patchGiven bool) [3]int {
+	switch {
+	case !minorGiven:
+		return [3]int{major + 1, 0, 0}
+	case !patchGiven:
+		if major > 0 {
+			return [3]int{major + 1, 0, 0}
+		}
+		return [3]int{0, minor + 1, 0}
+	default:
+		switch {
+		case major > 0:
+			return [3]int{major + 1, 0, 0}
+		case minor > 0:
+			return [3]int{0, minor + 1, 0}
+		default:
+			return [3]int{0, 0, patch + 1}
+		}
+	}
+}
+
+// parseBareToken expands a plain "major[.minor[.patch]]" token, where
+// a missing or wildcarded ("x", "X", "*") position widens the match
+// to the whole range below the next value of the previous position.
+func parseBareToken(token string) ([]comparator, error) {
+	nums, given, pre, err := parsePartialVersion(token)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !given[0]:
+		// "*", "x", "X" (or e.g. "x.x"): matches everything.
+		return nil, nil
+	case !given[1]:
+		return []comparator{
+			{opGE, New(nums[0], 0, 0)},
+			{opLT, New(nums[0]+1, 0, 0)},
+		}, nil
+	case !given[2]:
+		return []comparator{
+			{opGE, New(nums[0], nums[1], 0)},
+			{opLT, New(nums[0], nums[1]+1, 0)},
+		}, nil
+	default:
+		return []comparator{{opEQ, New(nums[0], nums[1], nums[2], preReleaseArgs(pre)...)}}, nil
+	}
+}
+
+// parsePartialVersion parses a (possibly partial or wildcarded)
+// "major[.minor[.patch]][-prerelease]" string.
+func parsePartialVersion(s string) (nums [3]int, given [3]bool, preRelease string, err error) {
+	core := s
+	if idx := strings.Index(s, "-"); idx >= 0 {
+		core = s[:idx]
+		preRelease = s[idx+1:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return nums, given, "", errors.New(ErrIllegalRangeFormat, errorMessages, s)
+	}
+	for i, part := range parts {
+		if part == "" {
+			return nums, given, "", errors.New(ErrIllegalRangeFormat, errorMessages, s)
+		}
+		if isWildcard(part) {
+			continue
+		}
+		n, cerr := strconv.Atoi(part)
+		if cerr != nil || n < 0 {
+			return nums, given, "", errors.New(ErrIllegalRangeFormat, errorMessages, s)
+		}
+		nums[i] = n
+		given[i] = true
+	}
+	return nums, given, preRelease, nil
+}
+
+// isWildcard returns true if part is a semver range wildcard.
+func isWildcard(part string) bool {
+	return part == "x" || part == "X" || part == "*"
+}
+
+// preReleaseArgs splits a pre-release string into the dot-separated
+// arguments expected by New().
+func preReleaseArgs(preRelease string) []string {
+	if preRelease == "" {
+		return nil
+	}
+	return strings.Split(preRelease, ".")
+}
+
+// EOF
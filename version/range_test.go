@@ -0,0 +1,100 @@
+// Tideland Go Library - Version - Unit Tests
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"github.com/tideland/golib/version"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestParseRangeContains checks a handful of range expressions against
+// versions that should and shouldn't satisfy them.
+func TestParseRangeContains(t *testing.T) {
+	tests := []struct {
+		rngstr string
+		vsnstr string
+		want   bool
+	}{
+		{">=1.2.3 <2.0.0", "1.2.3", true},
+		{">=1.2.3 <2.0.0", "1.9.9", true},
+		{">=1.2.3 <2.0.0", "2.0.0", false},
+		{">=1.2.3 <2.0.0", "1.2.2", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.9", true},
+		{"~1.2", "1.3.0", false},
+		{"^1.2.3", "1.9.9", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"1.2.x", "1.2.7", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.x", "1.9.9", true},
+		{"1.x", "2.0.0", false},
+		{">=1.0.0 || >=2.0.0", "2.5.0", true},
+		{">=1.0.0 || >=2.0.0", "0.5.0", false},
+		{"1.2.3-beta", "1.2.3-beta", true},
+		{">=1.2.3 <2.0.0", "1.2.3-beta", false},
+		{">=1.2.3-beta <2.0.0", "1.2.3-beta", true},
+	}
+	for _, test := range tests {
+		r, err := version.ParseRange(test.rngstr)
+		if err != nil {
+			t.Errorf("ParseRange(%q) returned error: %v", test.rngstr, err)
+			continue
+		}
+		v, err := version.Parse(test.vsnstr)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test.vsnstr, err)
+			continue
+		}
+		if got := r.Contains(v); got != test.want {
+			t.Errorf("ParseRange(%q).Contains(%q) = %v, want %v", test.rngstr, test.vsnstr, got, test.want)
+		}
+	}
+}
+
+// TestParseRangeInvalid checks that malformed range expressions are
+// rejected, including a blank "||" operand.
+func TestParseRangeInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		">=1.0.0 || ",
+		" || >=1.0.0",
+		">=1.0.0 ||  || <2.0.0",
+		">=1.a.0",
+	}
+	for _, rngstr := range tests {
+		if _, err := version.ParseRange(rngstr); err == nil {
+			t.Errorf("ParseRange(%q) returned no error, want ErrIllegalRangeFormat", rngstr)
+		}
+	}
+}
+
+// TestMustParseRangePanics checks that MustParseRange panics on an
+// invalid range expression.
+func TestMustParseRangePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseRange(\"\") did not panic")
+		}
+	}()
+	version.MustParseRange("")
+}
+
+// EOF
@@ -0,0 +1,68 @@
+// Tideland Go Library - Version
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"github.com/tideland/golib/errors"
+)
+
+//--------------------
+// CHANGE
+//--------------------
+
+// Change classifies the API-compatibility impact of a set of changes
+// made to the software a version describes.
+type Change int
+
+// The supported kinds of change, ordered from least to most
+// significant.
+const (
+	ChangeNone Change = iota
+	ChangePatch
+	ChangeMinor
+	ChangeMajor
+)
+
+//--------------------
+// SUGGEST
+//--------------------
+
+// Suggest returns the version following current after applying
+// change, following SemVer bump rules. While current's major version
+// is still 0, a breaking change only requires a minor bump. Any
+// pre-release of current is dropped once a component is bumped.
+func Suggest(current Version, change Change) (Version, error) {
+	if current.IsPseudo() {
+		return nil, errors.New(ErrCannotSuggest, errorMessages, current)
+	}
+	if change == ChangeNone && current.PreRelease() != "" {
+		return nil, errors.New(ErrCannotSuggest, errorMessages, current)
+	}
+	major, minor, patch := current.Major(), current.Minor(), current.Patch()
+	switch change {
+	case ChangeNone:
+		return New(major, minor, patch), nil
+	case ChangePatch:
+		return New(major, minor, patch+1), nil
+	case ChangeMinor:
+		return New(major, minor+1, 0), nil
+	case ChangeMajor:
+		if major == 0 {
+			// Still 0.x: breaking changes only bump the minor.
+			return New(major, minor+1, 0), nil
+		}
+		return New(major+1, 0, 0), nil
+	}
+	return nil, errors.New(ErrCannotSuggest, errorMessages, current)
+}
+
+// EOF
@@ -0,0 +1,73 @@
+// Tideland Go Library - Version - Unit Tests
+//
+// Copyright (C) 2014-2015 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package version_test
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tideland/golib/version"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestSuggest checks the SemVer bump rules, including the "still 0.x"
+// exception for breaking changes.
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		current string
+		change  version.Change
+		want    string
+	}{
+		{"1.2.3", version.ChangeNone, "1.2.3"},
+		{"1.2.3", version.ChangePatch, "1.2.4"},
+		{"1.2.3", version.ChangeMinor, "1.3.0"},
+		{"1.2.3", version.ChangeMajor, "2.0.0"},
+		{"0.2.3", version.ChangeMajor, "0.3.0"},
+		{"0.2.3", version.ChangeMinor, "0.3.0"},
+		{"0.2.3", version.ChangePatch, "0.2.4"},
+	}
+	for _, test := range tests {
+		current, err := version.Parse(test.current)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", test.current, err)
+		}
+		got, err := version.Suggest(current, test.change)
+		if err != nil {
+			t.Errorf("Suggest(%q, %v) returned error: %v", test.current, test.change, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("Suggest(%q, %v) = %q, want %q", test.current, test.change, got.String(), test.want)
+		}
+	}
+}
+
+// TestSuggestInvalid checks that Suggest refuses a pseudo-version or a
+// pre-release that ChangeNone would otherwise have to silently drop.
+func TestSuggestInvalid(t *testing.T) {
+	pseudo := version.PseudoVersion(version.New(1, 2, 3), time.Now(), "daa7c04131f5")
+	if _, err := version.Suggest(pseudo, version.ChangePatch); err == nil {
+		t.Error("Suggest(pseudo, ChangePatch) returned no error, want ErrCannotSuggest")
+	}
+	preRelease, err := version.Parse("1.2.3-beta")
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", "1.2.3-beta", err)
+	}
+	if _, err := version.Suggest(preRelease, version.ChangeNone); err == nil {
+		t.Error("Suggest(preRelease, ChangeNone) returned no error, want ErrCannotSuggest")
+	}
+}
+
+// EOF
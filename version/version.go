@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tideland/golib/errors"
 )
@@ -52,6 +53,17 @@ type Version interface {
 
 	// Less returns true if this version is less than the passed one.
 	Less(cv Version) bool
+
+	// IsPseudo returns true if the version is a Go module pseudo-version.
+	IsPseudo() bool
+
+	// Timestamp returns the embedded UTC timestamp of a pseudo-version.
+	// It returns the zero time for a version that isn't a pseudo-version.
+	Timestamp() time.Time
+
+	// Revision returns the embedded revision of a pseudo-version. It
+	// returns the empty string for a version that isn't a pseudo-version.
+	Revision() string
 }
 
 // vsn implements the version interface.
@@ -61,6 +73,10 @@ type vsn struct {
 	patch      int
 	preRelease []string
 	metadata   []string
+	pseudo     bool
+	pseudoBare bool
+	timestamp  time.Time
+	revision   string
 }
 
 // New returns a simple version instance. Parts of pre-release
@@ -96,8 +112,12 @@ func New(major, minor, patch int, prmds ...string) Version {
 	return v
 }
 
-// Parse retrieves a version out of a string.
+// Parse retrieves a version out of a string. Go module pseudo-versions
+// (see ParsePseudo) are recognized and returned as pseudo versions.
 func Parse(vsnstr string) (Version, error) {
+	if v, err := ParsePseudo(vsnstr); err == nil {
+		return v, nil
+	}
 	// Split version, pre-release, and metadata.
 	npmstrs, err := splitVersionString(vsnstr)
 	if err != nil {
@@ -168,7 +188,11 @@ func (v *vsn) Less(cv Version) bool {
 	if v.patch > cv.Patch() {
 		return false
 	}
-	// Simple comparing done, now the pre-release is interesting.
+	// Simple comparing done, now the pre-release (and, for
+	// pseudo-versions, the embedded timestamp) is interesting.
+	if v.pseudo || cv.IsPseudo() {
+		return lessPseudo(v, cv)
+	}
 	cvpr := []string{}
 	if cvprs := cv.PreRelease(); len(cvprs) > 0 {
 		cvpr = strings.Split(cvprs, ".")
@@ -176,9 +200,38 @@ func (v *vsn) Less(cv Version) bool {
 	return less(v.preRelease, cvpr)
 }
 
-// String returns the version as string.
+// IsPseudo returns true if the version is a Go module pseudo-version.
+func (v *vsn) IsPseudo() bool {
+	return v.pseudo
+}
+
+// Timestamp returns the embedded UTC timestamp of a pseudo-version.
+func (v *vsn) Timestamp() time.Time {
+	return v.timestamp
+}
+
+// Revision returns the embedded revision of a pseudo-version.
+func (v *vsn) Revision() string {
+	return v.revision
+}
+
+// String returns the version as string. Pseudo-versions render their
+// canonical "-[<pre>.]0.<timestamp>-<revision>" (or bare "-<timestamp>
+// -<revision>" when derived from no base tag at all) suffix instead of
+// the usual pre-release/metadata.
 func (v *vsn) String() string {
 	vs := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.pseudo {
+		switch {
+		case len(v.preRelease) > 0:
+			vs += "-" + v.PreRelease() + ".0."
+		case v.pseudoBare:
+			vs += "-"
+		default:
+			vs += "-0."
+		}
+		return vs + v.timestamp.UTC().Format(pseudoTimestampFormat) + "-" + v.revision
+	}
 	if len(v.preRelease) > 0 {
 		vs += "-" + v.PreRelease()
 	}